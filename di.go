@@ -1,151 +1,229 @@
 // /di/di.go
 
+// Package di is the public face of the module: a thin wrapper around
+// internal/container, the single DI implementation backing every
+// capability below (name- and type-based registration, auto-wiring, the
+// declared dependency graph, scopes, and the lifecycle event stream).
 package di
 
 import (
-	"fmt"
-	"sync"
+	"context"
 
+	"github.com/goletan/di/internal/container"
 	"go.uber.org/zap"
 )
 
-// Container manages the dependencies and lifecycle of services.
-type Container struct {
-	services    map[string]interface{}
-	singletons  map[string]func() interface{}
-	instances   map[string]interface{}
-	preInit     map[string]func()
-	postDestroy map[string]func()
-	mu          sync.RWMutex
-	logger      *zap.Logger
+// LifetimeType defines how a registered service's instance is shared across
+// resolutions.
+type LifetimeType = container.LifetimeType
+
+const (
+	// LifetimeSingleton constructs the service once and reuses the instance.
+	LifetimeSingleton = container.LifetimeSingleton
+	// LifetimeTransient constructs a new instance on every resolution.
+	LifetimeTransient = container.LifetimeTransient
+	// LifetimeScoped constructs one instance per Scope for services added
+	// with Register and resolved via Scope.Resolve. RegisterByType and
+	// RegisterAuto services with this lifetime are not tied to a Scope and
+	// are constructed anew on every resolution.
+	LifetimeScoped = container.LifetimeScoped
+)
+
+// Option configures a single Register call.
+type Option = container.Option
+
+// WithInitHook attaches a function run once, immediately before a
+// singleton's constructor is invoked for the first time.
+func WithInitHook(fn func()) Option { return container.WithInitHook(fn) }
+
+// WithDestroyHook attaches a function run when the service is torn down via
+// Destroy, Stop, or Scope.Close.
+func WithDestroyHook(fn func()) Option { return container.WithDestroyHook(fn) }
+
+// RegisterAutoOption configures a RegisterAuto registration.
+type RegisterAutoOption = container.RegisterAutoOption
+
+// WithNamedArg overrides auto-wiring for the constructor parameter at
+// paramIndex (0-based), binding it to a specific RegisterAuto registration by
+// name. Use one WithNamedArg per parameter that needs an override, which lets
+// two parameters of the same type be told apart.
+func WithNamedArg(paramIndex int, serviceName string) RegisterAutoOption {
+	return container.WithNamedArg(paramIndex, serviceName)
 }
 
-// NewContainer creates a new DI container.
-func NewContainer(logger *zap.Logger) *Container {
-	return &Container{
-		services:    make(map[string]interface{}),
-		singletons:  make(map[string]func() interface{}),
-		instances:   make(map[string]interface{}),
-		preInit:     make(map[string]func()),
-		postDestroy: make(map[string]func()),
-		logger:      logger,
-	}
+// DuplicateServiceError is returned by RegisterByType when a constructor for
+// the same concrete type has already been registered.
+type DuplicateServiceError = container.DuplicateServiceError
+
+// ErrServiceNotFound is returned by Service when no registered type is
+// assignable to the requested target.
+var ErrServiceNotFound = container.ErrServiceNotFound
+
+// ErrResolutionCycle is returned when auto-wiring a constructor's parameters
+// would require resolving a service already under construction.
+var ErrResolutionCycle = container.ErrResolutionCycle
+
+// ErrScopeRequired is returned by Resolve when name was registered with
+// LifetimeScoped; resolve it via a Scope (see NewScope) instead.
+var ErrScopeRequired = container.ErrScopeRequired
+
+// ErrCyclicDependency is returned by Start when the registered dependency
+// graph contains a cycle.
+var ErrCyclicDependency = container.ErrCyclicDependency
+
+// ErrUnknownDependency is returned by Start when a service declares a
+// dependsOn name that was never registered.
+var ErrUnknownDependency = container.ErrUnknownDependency
+
+// Event describes a single lifecycle occurrence within a Container, emitted
+// to every active Subscribe channel.
+type Event = container.Event
+
+// EventKind identifies the kind of lifecycle event emitted by a Container.
+type EventKind = container.EventKind
+
+const (
+	EventRegistered      = container.EventRegistered
+	EventPreInit         = container.EventPreInit
+	EventResolved        = container.EventResolved
+	EventConstructFailed = container.EventConstructFailed
+	EventDestroyed       = container.EventDestroyed
+	EventDropped         = container.EventDropped
+)
+
+// Scope carries request-lifetime instances for services registered with
+// LifetimeScoped.
+type Scope = container.Scope
+
+// ContextWithScope returns a copy of ctx carrying scope, retrievable via
+// ScopeFromContext.
+func ContextWithScope(ctx context.Context, scope *Scope) context.Context {
+	return container.ContextWithScope(ctx, scope)
 }
 
-// Register adds a new service to the DI container.
-func (c *Container) Register(name string, service interface{}) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.services[name] = service
-	c.logger.Info("Service registered", zap.String("service_name", name))
+// ScopeFromContext returns the Scope previously attached to ctx via
+// ContextWithScope, if any.
+func ScopeFromContext(ctx context.Context) (*Scope, bool) {
+	return container.ScopeFromContext(ctx)
 }
 
-// RegisterSingleton registers a service as a singleton, ensuring only one instance is used.
-func (c *Container) RegisterSingleton(name string, constructor func() interface{}) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.singletons[name] = constructor
-	c.logger.Info("Singleton service registered", zap.String("service_name", name))
+// Container manages the dependencies and lifecycle of services.
+type Container struct {
+	internal *container.Container
 }
 
-// RegisterPreInit registers a function that will be called before initializing a service.
-func (c *Container) RegisterPreInit(name string, fn func()) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.preInit[name] = fn
-	c.logger.Info("Pre-initialization hook registered", zap.String("service_name", name))
+// NewContainer creates a new DI container.
+func NewContainer(logger *zap.Logger) *Container {
+	return &Container{internal: container.NewContainer(logger)}
 }
 
-// RegisterPostDestroy registers a function that will be called after destroying a service.
-func (c *Container) RegisterPostDestroy(name string, fn func()) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.postDestroy[name] = fn
-	c.logger.Info("Post-destroy hook registered", zap.String("service_name", name))
+// Register adds constructor under name with the given lifetime. Options
+// (WithInitHook, WithDestroyHook) attach lifecycle hooks at registration
+// time.
+func (c *Container) Register(name string, constructor func() interface{}, lifetime LifetimeType, opts ...Option) {
+	c.internal.Register(name, constructor, lifetime, opts...)
 }
 
 // Resolve retrieves a service by name from the DI container.
 func (c *Container) Resolve(name string) (interface{}, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	// Check if the service is registered as a singleton and initialize it if needed
-	if constructor, exists := c.singletons[name]; exists {
-		if instance, found := c.instances[name]; found {
-			return instance, nil
-		}
-		// Upgrade lock to initialize the singleton instance
-		c.mu.RUnlock()
-		c.mu.Lock()
-		defer c.mu.Unlock()
-		if instance, found := c.instances[name]; found {
-			// Check again to avoid race conditions
-			return instance, nil
-		}
-		if preInit, exists := c.preInit[name]; exists {
-			preInit()
-		}
-		instance := constructor()
-		c.instances[name] = instance
-		c.logger.Info("Singleton service initialized", zap.String("service_name", name))
-		return instance, nil
-	}
-
-	// Check if the service is registered as a regular service
-	service, exists := c.services[name]
-	if !exists {
-		return nil, fmt.Errorf("service %s not found", name)
-	}
-	return service, nil
+	return c.internal.Resolve(name)
 }
 
 // MustResolve retrieves a service and panics if not found, useful for essential services.
 func (c *Container) MustResolve(name string) interface{} {
-	service, err := c.Resolve(name)
-	if err != nil {
-		panic(fmt.Sprintf("failed to resolve service: %s", err))
-	}
-	return service
+	return c.internal.MustResolve(name)
 }
 
-// Destroy removes a service from the container and calls the post-destroy hook if available.
+// Destroy removes a service from the container, invoking its postDestroy
+// hook (if any) first.
 func (c *Container) Destroy(name string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	if _, exists := c.services[name]; exists {
-		delete(c.services, name)
-		c.logger.Info("Service destroyed", zap.String("service_name", name))
-	}
-	if _, exists := c.instances[name]; exists {
-		delete(c.instances, name)
-		if postDestroy, exists := c.postDestroy[name]; exists {
-			postDestroy()
-		}
-		c.logger.Info("Singleton instance destroyed", zap.String("service_name", name))
-	}
-}
-
-// RegisterTransient registers a transient service, always providing a new instance.
+	c.internal.Destroy(name)
+}
+
+// RegisterByType registers a constructor indexed by the concrete type it
+// returns, so consumers can later request it by type via Service instead of
+// by name.
+func (c *Container) RegisterByType(constructor interface{}, lifetime LifetimeType) error {
+	return c.internal.RegisterByType(constructor, lifetime)
+}
+
+// Service resolves a registered constructor into target, which must be a
+// non-nil pointer to an interface or concrete type.
+func (c *Container) Service(target interface{}) error {
+	return c.internal.Service(target)
+}
+
+// RegisterAuto registers constructor under name for auto-wiring: each of its
+// parameters is resolved, at call time, against a previously RegisterByType
+// registration assignable to that parameter's type, or against a named
+// RegisterAuto binding supplied via WithNamedArg.
+func (c *Container) RegisterAuto(name string, constructor interface{}, lifetime LifetimeType, opts ...RegisterAutoOption) error {
+	return c.internal.RegisterAuto(name, constructor, lifetime, opts...)
+}
+
+// RegisterWithDeps registers a service whose constructor receives its
+// declared dependencies, resolved by name, as a map. Edges recorded here
+// drive the topological construction and teardown performed by Start and
+// Stop.
+func (c *Container) RegisterWithDeps(name string, constructor func(deps map[string]interface{}) interface{}, lifetime LifetimeType, dependsOn ...string) {
+	c.internal.RegisterWithDeps(name, constructor, lifetime, dependsOn...)
+}
+
+// Start verifies the dependency graph built from RegisterWithDeps
+// registrations and constructs each service in topological order.
+func (c *Container) Start(ctx context.Context) error {
+	return c.internal.Start(ctx)
+}
+
+// Stop tears services started by Start down in reverse topological order.
+func (c *Container) Stop(ctx context.Context) error {
+	return c.internal.Stop(ctx)
+}
+
+// NewScope creates a Scope bound to the container, ready to resolve scoped
+// services.
+func (c *Container) NewScope() *Scope {
+	return c.internal.NewScope()
+}
+
+// Subscribe returns a channel of lifecycle events, buffered to buf, and an
+// unsubscribe function that stops delivery and closes the channel.
+func (c *Container) Subscribe(buf int) (<-chan Event, func()) {
+	return c.internal.Subscribe(buf)
+}
+
+// DroppedEvents returns the number of events dropped so far because a
+// subscriber's buffer was full.
+func (c *Container) DroppedEvents() uint64 {
+	return c.internal.DroppedEvents()
+}
+
+// Deprecated: RegisterSingleton forwards to Register with LifetimeSingleton.
+func (c *Container) RegisterSingleton(name string, constructor func() interface{}) {
+	c.internal.Register(name, constructor, LifetimeSingleton)
+}
+
+// Deprecated: RegisterTransient forwards to Register with LifetimeTransient.
 func (c *Container) RegisterTransient(name string, constructor func() interface{}) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.services[name] = constructor
-	c.logger.Info("Transient service registered", zap.String("service_name", name))
+	c.internal.Register(name, constructor, LifetimeTransient)
+}
+
+// Deprecated: RegisterPreInit forwards to Register's WithInitHook option. It
+// may be called before or after the service itself is registered.
+func (c *Container) RegisterPreInit(name string, fn func()) {
+	c.internal.RegisterPreInit(name, fn)
+}
+
+// Deprecated: RegisterPostDestroy forwards to Register's WithDestroyHook
+// option. It may be called before or after the service itself is
+// registered.
+func (c *Container) RegisterPostDestroy(name string, fn func()) {
+	c.internal.RegisterPostDestroy(name, fn)
 }
 
-// ResolveTransient retrieves a new instance of a transient service by name.
+// Deprecated: ResolveTransient forwards to Resolve, which already constructs
+// a fresh instance of a transient service on each resolution. Like Resolve,
+// it returns ErrScopeRequired for a LifetimeScoped service.
 func (c *Container) ResolveTransient(name string) (interface{}, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	service, exists := c.services[name]
-	if !exists {
-		return nil, fmt.Errorf("service %s not found", name)
-	}
-
-	constructor, ok := service.(func() interface{})
-	if !ok {
-		return nil, fmt.Errorf("service %s is not a transient constructor", name)
-	}
-	return constructor(), nil
+	return c.internal.Resolve(name)
 }
@@ -0,0 +1,310 @@
+// /di/di_features_test.go
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goletan/di"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Greeter is resolved by type in the RegisterByType/Service tests below.
+type Greeter interface {
+	Greet() string
+}
+
+// EnglishGreeter is a concrete Greeter implementation.
+type EnglishGreeter struct{}
+
+func (g *EnglishGreeter) Greet() string { return "hello" }
+
+// TestRegisterByTypeAndService tests registering a constructor by its
+// concrete type and resolving it by type via Service.
+func TestRegisterByTypeAndService(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	if err := container.RegisterByType(func() *EnglishGreeter {
+		return &EnglishGreeter{}
+	}, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected RegisterByType to succeed, got error: %v", err)
+	}
+
+	var greeter Greeter
+	if err := container.Service(&greeter); err != nil {
+		t.Fatalf("Expected Service to resolve Greeter, got error: %v", err)
+	}
+	if greeter.Greet() != "hello" {
+		t.Errorf("Expected greeting 'hello', got '%s'", greeter.Greet())
+	}
+
+	// Singleton lifetime: resolving again must return the same instance.
+	var again Greeter
+	if err := container.Service(&again); err != nil {
+		t.Fatalf("Expected second Service resolution to succeed, got error: %v", err)
+	}
+	if greeter != again {
+		t.Errorf("Expected the same instance for singleton type registration, got different instances")
+	}
+}
+
+// TestRegisterByTypeDuplicate tests that registering two constructors for the
+// same concrete type is rejected.
+func TestRegisterByTypeDuplicate(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	ctor := func() *EnglishGreeter { return &EnglishGreeter{} }
+	if err := container.RegisterByType(ctor, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected first RegisterByType to succeed, got error: %v", err)
+	}
+
+	err := container.RegisterByType(ctor, di.LifetimeSingleton)
+	var dup *di.DuplicateServiceError
+	if !errors.As(err, &dup) {
+		t.Fatalf("Expected DuplicateServiceError, got %v", err)
+	}
+}
+
+// TestRegisterByTypeConstructorError tests that an error returned by a
+// RegisterByType constructor (the (T, error) form) propagates from Service
+// instead of being silently swallowed.
+func TestRegisterByTypeConstructorError(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	wantErr := errors.New("greeter unavailable")
+	if err := container.RegisterByType(func() (*EnglishGreeter, error) {
+		return nil, wantErr
+	}, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected RegisterByType to succeed, got error: %v", err)
+	}
+
+	var greeter Greeter
+	err := container.Service(&greeter)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Expected Service to surface the constructor error, got: %v", err)
+	}
+}
+
+// TestServiceNotFound tests that Service reports ErrServiceNotFound when no
+// compatible type has been registered.
+func TestServiceNotFound(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	var greeter Greeter
+	err := container.Service(&greeter)
+	if !errors.Is(err, di.ErrServiceNotFound) {
+		t.Fatalf("Expected ErrServiceNotFound, got %v", err)
+	}
+}
+
+// Config and Repository are used to exercise constructor auto-wiring below.
+type Config struct {
+	DSN string
+}
+
+type Repository struct {
+	Config *Config
+}
+
+// TestRegisterAutoWiresParameterByType tests that RegisterAuto resolves a
+// constructor parameter against a RegisterByType registration assignable to
+// its type.
+func TestRegisterAutoWiresParameterByType(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	if err := container.RegisterByType(func() *Config {
+		return &Config{DSN: "postgres://localhost"}
+	}, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected RegisterByType to succeed, got error: %v", err)
+	}
+
+	if err := container.RegisterAuto("repository", func(cfg *Config) *Repository {
+		return &Repository{Config: cfg}
+	}, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+
+	resolved, err := container.Resolve("repository")
+	if err != nil {
+		t.Fatalf("Expected repository to be resolved, got error: %v", err)
+	}
+	repo, ok := resolved.(*Repository)
+	if !ok {
+		t.Fatalf("Expected *Repository, got %T", resolved)
+	}
+	if repo.Config == nil || repo.Config.DSN != "postgres://localhost" {
+		t.Errorf("Expected repository to be wired with the registered *Config, got %+v", repo.Config)
+	}
+}
+
+// TestRegisterAutoNamedArg tests that WithNamedArg overrides the type-based
+// default and binds a parameter to a specific RegisterAuto registration.
+func TestRegisterAutoNamedArg(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	if err := container.RegisterAuto("primaryConfig", func() *Config {
+		return &Config{DSN: "postgres://primary"}
+	}, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+
+	if err := container.RegisterAuto("repository", func(cfg *Config) *Repository {
+		return &Repository{Config: cfg}
+	}, di.LifetimeSingleton, di.WithNamedArg(0, "primaryConfig")); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+
+	resolved, err := container.Resolve("repository")
+	if err != nil {
+		t.Fatalf("Expected repository to be resolved, got error: %v", err)
+	}
+	repo := resolved.(*Repository)
+	if repo.Config.DSN != "postgres://primary" {
+		t.Errorf("Expected repository to be wired with the named 'primaryConfig' binding, got %+v", repo.Config)
+	}
+}
+
+// Pair holds two *Config parameters of the same type, used to exercise
+// disambiguating them by position.
+type Pair struct {
+	Primary   *Config
+	Secondary *Config
+}
+
+// TestRegisterAutoNamedArgDisambiguatesSameType tests that WithNamedArg binds
+// each constructor parameter independently by position, so two parameters of
+// the same type can resolve to different named registrations.
+func TestRegisterAutoNamedArgDisambiguatesSameType(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	if err := container.RegisterAuto("primaryConfig", func() *Config {
+		return &Config{DSN: "postgres://primary"}
+	}, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+	if err := container.RegisterAuto("secondaryConfig", func() *Config {
+		return &Config{DSN: "postgres://secondary"}
+	}, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+
+	if err := container.RegisterAuto("pair", func(primary, secondary *Config) *Pair {
+		return &Pair{Primary: primary, Secondary: secondary}
+	}, di.LifetimeSingleton,
+		di.WithNamedArg(0, "primaryConfig"),
+		di.WithNamedArg(1, "secondaryConfig"),
+	); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+
+	resolved, err := container.Resolve("pair")
+	if err != nil {
+		t.Fatalf("Expected pair to be resolved, got error: %v", err)
+	}
+	pair := resolved.(*Pair)
+	if pair.Primary.DSN != "postgres://primary" {
+		t.Errorf("Expected first *Config parameter bound to 'primaryConfig', got %+v", pair.Primary)
+	}
+	if pair.Secondary.DSN != "postgres://secondary" {
+		t.Errorf("Expected second *Config parameter bound to 'secondaryConfig', got %+v", pair.Secondary)
+	}
+}
+
+// TestRegisterAutoConstructorError tests that an error returned by a
+// RegisterAuto constructor (the (T, error) form) propagates from Resolve
+// instead of being silently swallowed.
+func TestRegisterAutoConstructorError(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	wantErr := errors.New("repository unavailable")
+	if err := container.RegisterAuto("repository", func() (*Repository, error) {
+		return nil, wantErr
+	}, di.LifetimeSingleton); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+
+	_, err := container.Resolve("repository")
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Expected Resolve to surface the constructor error, got: %v", err)
+	}
+}
+
+// TestRegisterAutoResolutionCycle tests that a cyclic auto-wiring chain is
+// reported as ErrResolutionCycle instead of recursing forever.
+func TestRegisterAutoResolutionCycle(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	if err := container.RegisterAuto("a", func(b *ServiceB) *ServiceA {
+		return &ServiceA{}
+	}, di.LifetimeSingleton, di.WithNamedArg(0, "b")); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+	if err := container.RegisterAuto("b", func(a *ServiceA) *ServiceB {
+		return &ServiceB{}
+	}, di.LifetimeSingleton, di.WithNamedArg(0, "a")); err != nil {
+		t.Fatalf("Expected RegisterAuto to succeed, got error: %v", err)
+	}
+
+	_, err := container.Resolve("a")
+	if !errors.Is(err, di.ErrResolutionCycle) {
+		t.Fatalf("Expected ErrResolutionCycle, got %v", err)
+	}
+}
+
+// TestSubscribeEventOrdering tests that a subscriber observes the expected
+// preInit -> resolved -> destroyed ordering for a singleton service.
+func TestSubscribeEventOrdering(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+	container := di.NewContainer(logger)
+
+	events, unsubscribe := container.Subscribe(8)
+	defer unsubscribe()
+
+	container.RegisterSingleton("eventedService", func() interface{} {
+		return &MockService{name: "EventedService"}
+	})
+
+	if _, err := container.Resolve("eventedService"); err != nil {
+		t.Fatalf("Expected service to be resolved, got error: %v", err)
+	}
+	container.Destroy("eventedService")
+
+	var kinds []di.EventKind
+	for i := 0; i < 4; i++ {
+		select {
+		case evt := <-events:
+			kinds = append(kinds, evt.Kind)
+		default:
+		}
+	}
+
+	want := []di.EventKind{di.EventRegistered, di.EventPreInit, di.EventResolved, di.EventDestroyed}
+	if len(kinds) != len(want) {
+		t.Fatalf("Expected %d events, got %d: %v", len(want), len(kinds), kinds)
+	}
+	for i, k := range want {
+		if kinds[i] != k {
+			t.Errorf("Expected event %d to be %v, got %v", i, k, kinds[i])
+		}
+	}
+}
@@ -3,6 +3,7 @@ package di_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/goletan/di"
 	"go.uber.org/zap"
@@ -14,6 +15,10 @@ type MockService struct {
 	name string
 }
 
+func (ms *MockService) Name() string {
+	return ms.name
+}
+
 // ServiceA depends on ServiceB
 type ServiceA struct {
 	ServiceB *ServiceB
@@ -24,27 +29,22 @@ type ServiceB struct {
 	ServiceA *ServiceA
 }
 
-func (ms *MockService) Name() string {
-	return ms.name
+func newTestContainer() *di.Container {
+	core, _ := observer.New(zap.InfoLevel)
+	return di.NewContainer(zap.New(core))
 }
 
 // TestContainerRegistration tests the registration and resolution of services in the DI container.
 func TestContainerRegistration(t *testing.T) {
-	core, _ := observer.New(zap.InfoLevel)
-	logger := zap.New(core)
-	container := di.NewContainer(logger)
+	container := newTestContainer()
 
-	// Registering a mock service
 	mockService := &MockService{name: "TestService"}
-	container.Register("mockService", mockService)
+	container.Register("mockService", func() interface{} { return mockService }, di.LifetimeSingleton)
 
-	// Resolving the registered service
 	resolved, err := container.Resolve("mockService")
 	if err != nil {
 		t.Fatalf("Expected service to be resolved, got error: %v", err)
 	}
-
-	// Type assertion to validate resolved type
 	if resolved.(*MockService).Name() != "TestService" {
 		t.Errorf("Expected service name 'TestService', got '%s'", resolved.(*MockService).Name())
 	}
@@ -52,9 +52,7 @@ func TestContainerRegistration(t *testing.T) {
 
 // TestResolveUnregisteredService tests resolution of an unregistered service.
 func TestResolveUnregisteredService(t *testing.T) {
-	core, _ := observer.New(zap.InfoLevel)
-	logger := zap.New(core)
-	container := di.NewContainer(logger)
+	container := newTestContainer()
 
 	_, err := container.Resolve("unregisteredService")
 	if err == nil {
@@ -62,27 +60,24 @@ func TestResolveUnregisteredService(t *testing.T) {
 	}
 }
 
-// TestCircularDependency tests manual handling of circular dependencies.
+// TestCircularDependency tests manual handling of circular dependencies,
+// which RegisterWithDeps now supersedes for declared graphs (see
+// RegisterWithDeps, Start, and Stop) but which plain Register/Resolve still
+// leaves to the caller.
 func TestCircularDependency(t *testing.T) {
-	core, _ := observer.New(zap.InfoLevel)
-	logger := zap.New(core)
-	container := di.NewContainer(logger)
+	container := newTestContainer()
 
 	serviceA := &ServiceA{}
 	serviceB := &ServiceB{}
 
-	// Register both services
-	container.Register("serviceA", serviceA)
-	container.Register("serviceB", serviceB)
+	container.Register("serviceA", func() interface{} { return serviceA }, di.LifetimeSingleton)
+	container.Register("serviceB", func() interface{} { return serviceB }, di.LifetimeSingleton)
 
-	// Trying to resolve circular dependencies manually (since our DI does not handle wiring these)
-	// Normally, DI frameworks that support circular dependencies would handle this.
 	resolvedA, err := container.Resolve("serviceA")
 	if err != nil {
 		t.Fatalf("Failed to resolve ServiceA: %v", err)
 	}
 
-	// Simulate circular assignment
 	resolvedA.(*ServiceA).ServiceB = serviceB
 	serviceB.ServiceA = resolvedA.(*ServiceA)
 
@@ -94,45 +89,161 @@ func TestCircularDependency(t *testing.T) {
 	}
 }
 
-// TestSingletonRegistration tests the registration and resolution of singleton services in the DI container.
-func TestSingletonRegistration(t *testing.T) {
-	core, _ := observer.New(zap.InfoLevel)
-	logger := zap.New(core)
-	container := di.NewContainer(logger)
-
-	// Registering a singleton service
-	container.RegisterSingleton("singletonService", func() interface{} {
-		return &MockService{name: "SingletonService"}
-	})
-
-	// Resolving the singleton service multiple times
-	resolved1, err1 := container.Resolve("singletonService")
-	if err1 != nil {
-		t.Fatalf("Expected singleton service to be resolved, got error: %v", err1)
+// TestResolveFromWithinConstructor tests that a constructor resolving a
+// sibling service by name from within its own closure — the boilerplate
+// pattern the package has always supported — completes rather than
+// deadlocking on the container's own lock.
+func TestResolveFromWithinConstructor(t *testing.T) {
+	container := newTestContainer()
+
+	var nestedErr error
+	container.Register("dep", func() interface{} { return &MockService{name: "dep"} }, di.LifetimeSingleton)
+	container.Register("service", func() interface{} {
+		dep, err := container.Resolve("dep")
+		nestedErr = err
+		return dep
+	}, di.LifetimeSingleton)
+
+	type result struct {
+		instance interface{}
+		err      error
 	}
-	resolved2, err2 := container.Resolve("singletonService")
-	if err2 != nil {
-		t.Fatalf("Expected singleton service to be resolved, got error: %v", err2)
+	done := make(chan result, 1)
+	go func() {
+		instance, err := container.Resolve("service")
+		done <- result{instance, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("Expected service to be resolved, got error: %v", res.err)
+		}
+		if nestedErr != nil {
+			t.Fatalf("Expected nested Resolve to succeed, got error: %v", nestedErr)
+		}
+		if res.instance.(*MockService).Name() != "dep" {
+			t.Errorf("Expected service to resolve to the nested 'dep' instance, got %+v", res.instance)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Resolve deadlocked on a constructor that resolves a sibling service")
 	}
+}
+
+// TestMustResolvePanicsOnMissingService tests that MustResolve panics when
+// the requested service was never registered.
+func TestMustResolvePanicsOnMissingService(t *testing.T) {
+	container := newTestContainer()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected MustResolve to panic for an unregistered service")
+		}
+	}()
+	container.MustResolve("missing")
+}
+
+// registrationCase exercises one way of registering and resolving a
+// singleton-or-transient service and its lifecycle hooks: either through the
+// Deprecated name-based shims, or through the unified options-based Register
+// API that backs them.
+type registrationCase struct {
+	name     string
+	register func(c *di.Container, preInit, postDestroy func())
+	lifetime di.LifetimeType
+}
 
-	// Validate that the resolved instances are the same
-	if resolved1 != resolved2 {
-		t.Errorf("Expected the same instance for singleton service, got different instances")
+var registrationCases = []registrationCase{
+	{
+		name: "legacy singleton shims",
+		register: func(c *di.Container, preInit, postDestroy func()) {
+			c.RegisterSingleton("svc", func() interface{} { return &MockService{name: "svc"} })
+			c.RegisterPreInit("svc", preInit)
+			c.RegisterPostDestroy("svc", postDestroy)
+		},
+		lifetime: di.LifetimeSingleton,
+	},
+	{
+		name: "options-based singleton",
+		register: func(c *di.Container, preInit, postDestroy func()) {
+			c.Register("svc", func() interface{} { return &MockService{name: "svc"} }, di.LifetimeSingleton,
+				di.WithInitHook(preInit),
+				di.WithDestroyHook(postDestroy),
+			)
+		},
+		lifetime: di.LifetimeSingleton,
+	},
+	{
+		name: "legacy transient shim",
+		register: func(c *di.Container, preInit, postDestroy func()) {
+			c.RegisterTransient("svc", func() interface{} { return &MockService{name: "svc"} })
+		},
+		lifetime: di.LifetimeTransient,
+	},
+	{
+		name: "options-based transient",
+		register: func(c *di.Container, preInit, postDestroy func()) {
+			c.Register("svc", func() interface{} { return &MockService{name: "svc"} }, di.LifetimeTransient)
+		},
+		lifetime: di.LifetimeTransient,
+	},
+}
+
+// TestRegistrationPaths runs the same singleton/transient/hook assertions
+// against every registration path the public API still supports, so the
+// legacy shims and the current options-based Register stay behaviorally
+// equivalent.
+func TestRegistrationPaths(t *testing.T) {
+	for _, tc := range registrationCases {
+		t.Run(tc.name, func(t *testing.T) {
+			container := newTestContainer()
+
+			preInitCalled := false
+			postDestroyCalled := false
+			tc.register(container,
+				func() { preInitCalled = true },
+				func() { postDestroyCalled = true },
+			)
+
+			resolved1, err := container.Resolve("svc")
+			if err != nil {
+				t.Fatalf("Expected service to be resolved, got error: %v", err)
+			}
+			resolved2, err := container.Resolve("svc")
+			if err != nil {
+				t.Fatalf("Expected service to be resolved, got error: %v", err)
+			}
+
+			switch tc.lifetime {
+			case di.LifetimeSingleton:
+				if resolved1 != resolved2 {
+					t.Errorf("Expected the same instance for singleton service, got different instances")
+				}
+				if !preInitCalled {
+					t.Errorf("Expected pre-initialization hook to be called, but it wasn't")
+				}
+				container.Destroy("svc")
+				if !postDestroyCalled {
+					t.Errorf("Expected post-destroy hook to be called, but it wasn't")
+				}
+			case di.LifetimeTransient:
+				if resolved1 == resolved2 {
+					t.Errorf("Expected different instances for transient service, got the same instance")
+				}
+			}
+		})
 	}
 }
 
-// TestTransientRegistration tests the registration and resolution of transient services in the DI container.
-func TestTransientRegistration(t *testing.T) {
-	core, _ := observer.New(zap.InfoLevel)
-	logger := zap.New(core)
-	container := di.NewContainer(logger)
+// TestResolveTransientShim tests that the Deprecated ResolveTransient shim
+// behaves like Resolve for a transient registration.
+func TestResolveTransientShim(t *testing.T) {
+	container := newTestContainer()
 
-	// Registering a transient service
 	container.RegisterTransient("transientService", func() interface{} {
 		return &MockService{name: "TransientService"}
 	})
 
-	// Resolving the transient service multiple times
 	resolved1, err1 := container.ResolveTransient("transientService")
 	if err1 != nil {
 		t.Fatalf("Expected transient service to be resolved, got error: %v", err1)
@@ -141,48 +252,7 @@ func TestTransientRegistration(t *testing.T) {
 	if err2 != nil {
 		t.Fatalf("Expected transient service to be resolved, got error: %v", err2)
 	}
-
-	// Validate that the resolved instances are different
 	if resolved1 == resolved2 {
 		t.Errorf("Expected different instances for transient service, got the same instance")
 	}
 }
-
-// TestLifecycleHooks tests the pre-initialization and post-destroy lifecycle hooks.
-func TestLifecycleHooks(t *testing.T) {
-	core, _ := observer.New(zap.InfoLevel)
-	logger := zap.New(core)
-	container := di.NewContainer(logger)
-
-	preInitCalled := false
-	postDestroyCalled := false
-
-	container.RegisterSingleton("hookedService", func() interface{} {
-		return &MockService{name: "HookedService"}
-	})
-
-	container.RegisterPreInit("hookedService", func() {
-		preInitCalled = true
-	})
-
-	container.RegisterPostDestroy("hookedService", func() {
-		postDestroyCalled = true
-	})
-
-	// Resolve the service to trigger pre-initialization
-	_, err := container.Resolve("hookedService")
-	if err != nil {
-		t.Fatalf("Expected hooked service to be resolved, got error: %v", err)
-	}
-
-	if !preInitCalled {
-		t.Errorf("Expected pre-initialization hook to be called, but it wasn't")
-	}
-
-	// Destroy the service to trigger post-destroy hook
-	container.Destroy("hookedService")
-
-	if !postDestroyCalled {
-		t.Errorf("Expected post-destroy hook to be called, but it wasn't")
-	}
-}
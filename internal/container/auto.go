@@ -0,0 +1,178 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrResolutionCycle is returned by RegisterAuto-resolved services when
+// auto-wiring their parameters would require resolving a service that is
+// already being constructed earlier in the same resolution chain.
+var ErrResolutionCycle = errors.New("di: resolution cycle detected")
+
+// autoServiceEntry holds a reflect-inspected constructor registered via
+// RegisterAuto, whose parameters are resolved automatically at call time.
+type autoServiceEntry struct {
+	constructor reflect.Value
+	lifetime    LifetimeType
+	namedArgs   map[int]string // parameter index -> override service name
+	instance    interface{}
+}
+
+type autoOptions struct {
+	namedArgs map[int]string
+}
+
+// RegisterAutoOption configures a RegisterAuto registration.
+type RegisterAutoOption func(*autoOptions)
+
+// WithNamedArg overrides auto-wiring for the constructor parameter at
+// paramIndex (0-based), binding it to a specific RegisterAuto registration by
+// name instead of the type-indexed default from RegisterByType. Keying by
+// position, rather than by parameter type, is what lets two parameters of the
+// same type be disambiguated — one WithNamedArg per parameter that needs an
+// override.
+func WithNamedArg(paramIndex int, serviceName string) RegisterAutoOption {
+	return func(o *autoOptions) {
+		o.namedArgs[paramIndex] = serviceName
+	}
+}
+
+// RegisterAuto registers constructor under name for auto-wiring: each of its
+// parameters is resolved, at call time, against a previously RegisterByType
+// registration assignable to that parameter's type, or against a named
+// RegisterAuto binding supplied via WithNamedArg. constructor must be a
+// function returning either T or (T, error).
+func (c *Container) RegisterAuto(name string, constructor interface{}, lifetime LifetimeType, opts ...RegisterAutoOption) error {
+	ctorVal := reflect.ValueOf(constructor)
+	ctorType := ctorVal.Type()
+	if ctorType.Kind() != reflect.Func {
+		return fmt.Errorf("di: constructor must be a function, got %s", ctorType.Kind())
+	}
+	if ctorType.NumOut() == 0 || ctorType.NumOut() > 2 {
+		return fmt.Errorf("di: constructor must return (T) or (T, error)")
+	}
+	if ctorType.NumOut() == 2 {
+		errType := reflect.TypeOf((*error)(nil)).Elem()
+		if !ctorType.Out(1).Implements(errType) {
+			return fmt.Errorf("di: constructor's second return value must be error")
+		}
+	}
+
+	options := &autoOptions{namedArgs: make(map[int]string)}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.autoServices[name] = &autoServiceEntry{
+		constructor: ctorVal,
+		lifetime:    lifetime,
+		namedArgs:   options.namedArgs,
+	}
+	c.logger.Info("Service registered for auto-wiring", zap.String("service_name", name), zap.String("lifetime", lifetime.String()))
+	c.emit(Event{Kind: EventRegistered, Name: name, Lifetime: lifetime, At: time.Now()})
+	return nil
+}
+
+// resolveAuto constructs (or, for singletons, reuses) the RegisterAuto
+// service registered under name, auto-wiring its parameters. visited tracks
+// the name:type chain of the current resolution, so a parameter that would
+// require resolving a service already under construction is reported as
+// ErrResolutionCycle instead of recursing forever. It locks c.mu only around
+// the map/cache reads and the singleton write, so constructors run with the
+// lock released.
+func (c *Container) resolveAuto(name string, visited []string) (interface{}, error) {
+	c.mu.RLock()
+	entry, exists := c.autoServices[name]
+	c.mu.RUnlock()
+	if !exists {
+		return nil, ErrServiceNotFound
+	}
+
+	key := name + ":" + entry.constructor.Type().String()
+	for _, v := range visited {
+		if v == key {
+			return nil, fmt.Errorf("%w: %s", ErrResolutionCycle, strings.Join(append(visited, key), " -> "))
+		}
+	}
+	visited = append(visited, key)
+
+	if entry.lifetime == LifetimeSingleton {
+		c.mu.RLock()
+		instance := entry.instance
+		c.mu.RUnlock()
+		if instance != nil {
+			return instance, nil
+		}
+	}
+
+	ctorType := entry.constructor.Type()
+	args := make([]reflect.Value, ctorType.NumIn())
+	for i := 0; i < ctorType.NumIn(); i++ {
+		arg, err := c.resolveAutoParam(i, ctorType.In(i), entry.namedArgs, visited)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+
+	results := entry.constructor.Call(args)
+	if len(results) == 2 && !results[1].IsNil() {
+		err := results[1].Interface().(error)
+		c.emit(Event{Kind: EventConstructFailed, Name: name, Lifetime: entry.lifetime, Err: err, At: time.Now()})
+		return nil, err
+	}
+
+	instance := results[0].Interface()
+	if entry.lifetime == LifetimeSingleton {
+		c.mu.Lock()
+		entry.instance = instance
+		c.mu.Unlock()
+	}
+	c.logger.Info("Service auto-resolved", zap.String("service_name", name))
+	c.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+	return instance, nil
+}
+
+// resolveAutoParam resolves a single constructor parameter at paramIndex for
+// resolveAuto, preferring a WithNamedArg override for that index and
+// otherwise scanning the type-indexed registrations from RegisterByType for
+// one assignable to paramType.
+func (c *Container) resolveAutoParam(paramIndex int, paramType reflect.Type, namedArgs map[int]string, visited []string) (reflect.Value, error) {
+	if boundName, ok := namedArgs[paramIndex]; ok {
+		instance, err := c.resolveAuto(boundName, visited)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(instance), nil
+	}
+
+	c.mu.RLock()
+	var svcType reflect.Type
+	var entry *typedServiceEntry
+	for st, e := range c.servicesByType {
+		if st.AssignableTo(paramType) {
+			svcType, entry = st, e
+			break
+		}
+	}
+	c.mu.RUnlock()
+
+	if entry == nil {
+		return reflect.Value{}, fmt.Errorf("di: no registration found for parameter of type %s: %w", paramType, ErrServiceNotFound)
+	}
+
+	instance, err := c.resolveTyped(svcType, entry)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(instance), nil
+}
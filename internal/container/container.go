@@ -2,16 +2,34 @@ package container
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 	"sync"
+	"time"
 
-	"github.com/goletan/observability/shared/logger"
 	"go.uber.org/zap"
 )
 
+// Container is the single DI implementation backing the public di package:
+// name-based registration with functional-option hooks, type-based
+// registration and resolution, auto-wired constructors, a declared
+// dependency graph with topological Start/Stop, request-scoped lifetimes,
+// and a lifecycle event stream all operate on this type.
 type Container struct {
-	services map[string]*serviceEntry
-	logger   *logger.ZapLogger
-	mu       sync.RWMutex
+	services       map[string]*serviceEntry
+	depServices    map[string]*depServiceEntry
+	servicesByType map[reflect.Type]*typedServiceEntry
+	autoServices   map[string]*autoServiceEntry
+	preInit        map[string]func()
+	postDestroy    map[string]func()
+	startOrder     []string
+	logger         *zap.Logger
+	mu             sync.RWMutex
+
+	eventMu          sync.RWMutex
+	subscribers      map[int]chan Event
+	nextSubscriberID int
+	droppedEvents    uint64
 }
 
 type serviceEntry struct {
@@ -20,14 +38,50 @@ type serviceEntry struct {
 	lifetime    LifetimeType
 }
 
-func NewContainer(log *logger.ZapLogger) *Container {
+// ErrScopeRequired is returned by Resolve when name was registered with
+// LifetimeScoped: scoped services only have a well-defined instance within a
+// Scope, so they must be resolved via Scope.Resolve (see NewScope) rather
+// than directly on the Container.
+var ErrScopeRequired = errors.New("service requires a scope: use Container.NewScope and Scope.Resolve")
+
+// Option configures a single Register call, e.g. WithInitHook or
+// WithDestroyHook. Callers must hold c.mu when an Option runs.
+type Option func(c *Container, name string)
+
+// WithInitHook attaches a function run once, immediately before a
+// singleton's constructor is invoked for the first time.
+func WithInitHook(fn func()) Option {
+	return func(c *Container, name string) {
+		c.preInit[name] = fn
+	}
+}
+
+// WithDestroyHook attaches a function run when the service is torn down via
+// Destroy, Stop, or Scope.Close.
+func WithDestroyHook(fn func()) Option {
+	return func(c *Container, name string) {
+		c.postDestroy[name] = fn
+	}
+}
+
+// NewContainer creates a new, empty DI container.
+func NewContainer(log *zap.Logger) *Container {
 	return &Container{
-		services: make(map[string]*serviceEntry),
-		logger:   log,
+		services:       make(map[string]*serviceEntry),
+		depServices:    make(map[string]*depServiceEntry),
+		servicesByType: make(map[reflect.Type]*typedServiceEntry),
+		autoServices:   make(map[string]*autoServiceEntry),
+		preInit:        make(map[string]func()),
+		postDestroy:    make(map[string]func()),
+		subscribers:    make(map[int]chan Event),
+		logger:         log,
 	}
 }
 
-func (c *Container) Register(name string, constructor func() interface{}, lifetime LifetimeType) {
+// Register adds constructor under name with the given lifetime. Options
+// (WithInitHook, WithDestroyHook) attach lifecycle hooks at registration
+// time.
+func (c *Container) Register(name string, constructor func() interface{}, lifetime LifetimeType, opts ...Option) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -35,31 +89,88 @@ func (c *Container) Register(name string, constructor func() interface{}, lifeti
 		constructor: constructor,
 		lifetime:    lifetime,
 	}
+	for _, opt := range opts {
+		opt(c, name)
+	}
 	c.logger.Info("Service registered", zap.String("service_name", name), zap.String("lifetime", lifetime.String()))
+	c.emit(Event{Kind: EventRegistered, Name: name, Lifetime: lifetime, At: time.Now()})
 }
 
+// RegisterPreInit attaches fn to name, to be run once immediately before
+// name's constructor is invoked for the first time. It may be called before
+// or after name itself is registered.
+func (c *Container) RegisterPreInit(name string, fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.preInit[name] = fn
+}
+
+// Resolve retrieves name's instance, constructing it (and, for singletons,
+// caching it) if needed. It returns an error if name was never registered.
+// The constructor (and any WithInitHook) runs with c.mu released, so a
+// constructor that itself calls back into the Container — e.g. to resolve a
+// sibling dependency by name — does not deadlock.
 func (c *Container) Resolve(name string) (interface{}, error) {
 	c.mu.RLock()
 	entry, exists := c.services[name]
-	c.mu.RUnlock()
-
 	if !exists {
+		_, isAuto := c.autoServices[name]
+		c.mu.RUnlock()
+		if isAuto {
+			return c.resolveAuto(name, nil)
+		}
 		c.logger.Error("Service not found", zap.String("service_name", name))
 		return nil, errors.New("service not found: " + name)
 	}
 
+	if entry.lifetime == LifetimeScoped {
+		c.mu.RUnlock()
+		return nil, fmt.Errorf("%w: %q", ErrScopeRequired, name)
+	}
+
 	if entry.lifetime == LifetimeSingleton && entry.instance != nil {
-		return entry.instance, nil
+		instance := entry.instance
+		c.mu.RUnlock()
+		c.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+		return instance, nil
 	}
+	c.mu.RUnlock()
 
-	instance := entry.constructor()
-	if entry.lifetime == LifetimeSingleton {
-		entry.instance = instance
+	if entry.lifetime != LifetimeSingleton {
+		instance := entry.constructor()
+		c.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+		return instance, nil
+	}
+
+	// Singleton, not yet built: re-check under the write lock in case another
+	// caller built it while we were unlocked, then release the lock again
+	// before running the preInit hook and constructor.
+	c.mu.Lock()
+	if entry.instance != nil {
+		instance := entry.instance
+		c.mu.Unlock()
+		c.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+		return instance, nil
+	}
+	preInit, hasPreInit := c.preInit[name]
+	c.mu.Unlock()
+
+	if hasPreInit {
+		preInit()
 	}
+	c.emit(Event{Kind: EventPreInit, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+
+	instance := entry.constructor()
+
+	c.mu.Lock()
+	entry.instance = instance
+	c.mu.Unlock()
 
+	c.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
 	return instance, nil
 }
 
+// MustResolve retrieves a service and panics if not found, useful for essential services.
 func (c *Container) MustResolve(name string) interface{} {
 	instance, err := c.Resolve(name)
 	if err != nil {
@@ -68,12 +179,18 @@ func (c *Container) MustResolve(name string) interface{} {
 	return instance
 }
 
+// Destroy removes name's registration, invoking its postDestroy hook (if
+// any) before it does.
 func (c *Container) Destroy(name string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if _, exists := c.services[name]; exists {
+		if postDestroy, ok := c.postDestroy[name]; ok {
+			postDestroy()
+		}
 		delete(c.services, name)
 		c.logger.Info("Service destroyed", zap.String("service_name", name))
+		c.emit(Event{Kind: EventDestroyed, Name: name, At: time.Now()})
 	}
 }
@@ -0,0 +1,107 @@
+package container
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// EventKind identifies the kind of lifecycle event emitted by a Container.
+type EventKind int
+
+const (
+	// EventRegistered fires when a service is registered, by name or by type.
+	EventRegistered EventKind = iota
+	// EventPreInit fires before the constructor of a singleton added with
+	// Register runs. Services built by RegisterByType, RegisterAuto, or Start
+	// do not emit it.
+	EventPreInit
+	// EventResolved fires after a service has been successfully resolved.
+	EventResolved
+	// EventConstructFailed fires when a constructor returns an error instead
+	// of a value.
+	EventConstructFailed
+	// EventDestroyed fires when a service instance is torn down.
+	EventDestroyed
+	// EventDropped names the dropping of an event for a subscriber whose
+	// buffer is full. The container never emits it: a drop is counted by
+	// DroppedEvents and logged as a warning carrying the dropped event's own
+	// kind.
+	EventDropped
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventRegistered:
+		return "Registered"
+	case EventPreInit:
+		return "PreInit"
+	case EventResolved:
+		return "Resolved"
+	case EventConstructFailed:
+		return "ConstructFailed"
+	case EventDestroyed:
+		return "Destroyed"
+	case EventDropped:
+		return "Dropped"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event describes a single lifecycle occurrence within a Container, emitted
+// to every active Subscribe channel.
+type Event struct {
+	Kind     EventKind
+	Name     string
+	Lifetime LifetimeType
+	Err      error
+	At       time.Time
+}
+
+// Subscribe returns a channel of lifecycle events, buffered to buf, and an
+// unsubscribe function that stops delivery and closes the channel. Events
+// are fanned out non-blockingly: a subscriber whose buffer is full has the
+// event dropped for it, with a warning logged and DroppedEvents incremented.
+func (c *Container) Subscribe(buf int) (<-chan Event, func()) {
+	ch := make(chan Event, buf)
+
+	c.eventMu.Lock()
+	id := c.nextSubscriberID
+	c.nextSubscriberID++
+	c.subscribers[id] = ch
+	c.eventMu.Unlock()
+
+	unsubscribe := func() {
+		c.eventMu.Lock()
+		delete(c.subscribers, id)
+		c.eventMu.Unlock()
+		close(ch)
+	}
+	return ch, unsubscribe
+}
+
+// DroppedEvents returns the number of events dropped so far because a
+// subscriber's buffer was full.
+func (c *Container) DroppedEvents() uint64 {
+	return atomic.LoadUint64(&c.droppedEvents)
+}
+
+// emit fans evt out to every active subscriber without blocking.
+func (c *Container) emit(evt Event) {
+	c.eventMu.RLock()
+	defer c.eventMu.RUnlock()
+
+	for _, ch := range c.subscribers {
+		select {
+		case ch <- evt:
+		default:
+			atomic.AddUint64(&c.droppedEvents, 1)
+			c.logger.Warn("Dropping event for slow subscriber",
+				zap.String("event_kind", evt.Kind.String()),
+				zap.String("service_name", evt.Name),
+			)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrCyclicDependency is returned by Start when the registered dependency
+// graph contains a cycle. The error message names the offending cycle.
+var ErrCyclicDependency = errors.New("cyclic dependency detected")
+
+// ErrUnknownDependency is returned by Start when a service declares a
+// dependsOn name that was never registered.
+var ErrUnknownDependency = errors.New("unknown dependency")
+
+// depServiceEntry describes a node in the dependency graph registered via
+// RegisterWithDeps.
+type depServiceEntry struct {
+	constructor func(deps map[string]interface{}) interface{}
+	lifetime    LifetimeType
+	dependsOn   []string
+	instance    interface{}
+}
+
+// RegisterWithDeps registers a service whose constructor receives its
+// declared dependencies, resolved by name, as a map. Edges recorded here
+// drive the topological startup and teardown performed by Start and Stop.
+func (c *Container) RegisterWithDeps(name string, constructor func(deps map[string]interface{}) interface{}, lifetime LifetimeType, dependsOn ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.depServices[name] = &depServiceEntry{
+		constructor: constructor,
+		lifetime:    lifetime,
+		dependsOn:   dependsOn,
+	}
+	c.logger.Info("Service registered with dependencies",
+		zap.String("service_name", name),
+		zap.Strings("depends_on", dependsOn),
+	)
+	c.emit(Event{Kind: EventRegistered, Name: name, Lifetime: lifetime, At: time.Now()})
+}
+
+// RegisterPostDestroy registers a function invoked for name when Stop tears
+// the service down.
+func (c *Container) RegisterPostDestroy(name string, fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.postDestroy[name] = fn
+}
+
+// Start verifies the dependency graph built from RegisterWithDeps
+// registrations, orders it topologically via Kahn's algorithm, and
+// constructs each service in that order, passing it its resolved
+// dependencies. It returns ErrCyclicDependency if the graph has a cycle, or
+// ErrUnknownDependency if a dependsOn name was never registered. Each
+// constructor runs with c.mu released, so a constructor that itself calls
+// back into the Container does not deadlock.
+func (c *Container) Start(ctx context.Context) error {
+	c.mu.Lock()
+	for name, entry := range c.depServices {
+		for _, dep := range entry.dependsOn {
+			if _, exists := c.depServices[dep]; !exists {
+				c.mu.Unlock()
+				return fmt.Errorf("%w: service %q depends on unregistered service %q", ErrUnknownDependency, name, dep)
+			}
+		}
+	}
+
+	order, err := c.topologicalOrder()
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		c.mu.RLock()
+		entry := c.depServices[name]
+		deps := make(map[string]interface{}, len(entry.dependsOn))
+		for _, dep := range entry.dependsOn {
+			deps[dep] = c.depServices[dep].instance
+		}
+		c.mu.RUnlock()
+
+		instance := entry.constructor(deps)
+
+		c.mu.Lock()
+		entry.instance = instance
+		c.mu.Unlock()
+
+		c.logger.Info("Service started", zap.String("service_name", name))
+		c.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+	}
+
+	c.mu.Lock()
+	c.startOrder = order
+	c.mu.Unlock()
+	return nil
+}
+
+// Stop tears services started by Start down in reverse topological order,
+// invoking any postDestroy hook registered for each service.
+func (c *Container) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := len(c.startOrder) - 1; i >= 0; i-- {
+		name := c.startOrder[i]
+		if fn, exists := c.postDestroy[name]; exists {
+			fn()
+		}
+		if entry, exists := c.depServices[name]; exists {
+			entry.instance = nil
+		}
+		c.logger.Info("Service stopped", zap.String("service_name", name))
+		c.emit(Event{Kind: EventDestroyed, Name: name, At: time.Now()})
+	}
+
+	c.startOrder = nil
+	return nil
+}
+
+// topologicalOrder computes a dependency-respecting construction order for
+// c.depServices using Kahn's algorithm. If nodes remain unvisited once the
+// queue drains, it runs a DFS coloring pass (white/gray/black) to recover
+// and report the offending cycle.
+func (c *Container) topologicalOrder() ([]string, error) {
+	dependents := make(map[string][]string)
+	inDegree := make(map[string]int)
+
+	for name, entry := range c.depServices {
+		if _, ok := inDegree[name]; !ok {
+			inDegree[name] = 0
+		}
+		for _, dep := range entry.dependsOn {
+			dependents[dep] = append(dependents[dep], name)
+			inDegree[name]++
+		}
+	}
+
+	var queue []string
+	for name, degree := range inDegree {
+		if degree == 0 {
+			queue = append(queue, name)
+		}
+	}
+
+	order := make([]string, 0, len(c.depServices))
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		order = append(order, name)
+
+		for _, dependent := range dependents[name] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	if len(order) == len(c.depServices) {
+		return order, nil
+	}
+
+	cycle := c.findCycle()
+	return nil, fmt.Errorf("%w: %s", ErrCyclicDependency, strings.Join(cycle, " -> "))
+}
+
+// findCycle runs a DFS coloring pass over c.depServices to recover a cycle
+// path for error reporting once topologicalOrder detects that Kahn's
+// algorithm could not consume every node.
+func (c *Container) findCycle() []string {
+	const (
+		white = iota
+		gray
+		black
+	)
+
+	color := make(map[string]int, len(c.depServices))
+	var path []string
+	var cycle []string
+
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		color[name] = gray
+		path = append(path, name)
+
+		for _, dep := range c.depServices[name].dependsOn {
+			switch color[dep] {
+			case gray:
+				start := 0
+				for i, n := range path {
+					if n == dep {
+						start = i
+						break
+					}
+				}
+				cycle = append(append([]string{}, path[start:]...), dep)
+				return true
+			case white:
+				if visit(dep) {
+					return true
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		color[name] = black
+		return false
+	}
+
+	for name := range c.depServices {
+		if color[name] == white {
+			if visit(name) {
+				return cycle
+			}
+		}
+	}
+	return cycle
+}
@@ -0,0 +1,152 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goletan/di/internal/container"
+)
+
+// TestStartTopologicalOrder tests that Start constructs services in an order
+// that respects dependsOn edges, passing each service its resolved
+// dependencies, and that Stop tears them down in the reverse order.
+func TestStartTopologicalOrder(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	var built []string
+
+	c.RegisterWithDeps("db", func(deps map[string]interface{}) interface{} {
+		built = append(built, "db")
+		return "db-instance"
+	}, container.LifetimeSingleton)
+
+	c.RegisterWithDeps("repo", func(deps map[string]interface{}) interface{} {
+		built = append(built, "repo")
+		if deps["db"] != "db-instance" {
+			t.Fatalf("Expected repo to receive db's instance, got %+v", deps["db"])
+		}
+		return "repo-instance"
+	}, container.LifetimeSingleton, "db")
+
+	c.RegisterWithDeps("api", func(deps map[string]interface{}) interface{} {
+		built = append(built, "api")
+		if deps["repo"] != "repo-instance" {
+			t.Fatalf("Expected api to receive repo's instance, got %+v", deps["repo"])
+		}
+		return "api-instance"
+	}, container.LifetimeSingleton, "repo")
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Expected Start to succeed, got error: %v", err)
+	}
+
+	want := []string{"db", "repo", "api"}
+	if len(built) != len(want) {
+		t.Fatalf("Expected %d services built, got %d: %v", len(want), len(built), built)
+	}
+	for i, name := range want {
+		if built[i] != name {
+			t.Errorf("Expected build order %v, got %v", want, built)
+			break
+		}
+	}
+
+	var destroyed []string
+	c.RegisterPostDestroy("db", func() { destroyed = append(destroyed, "db") })
+	c.RegisterPostDestroy("repo", func() { destroyed = append(destroyed, "repo") })
+	c.RegisterPostDestroy("api", func() { destroyed = append(destroyed, "api") })
+
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("Expected Stop to succeed, got error: %v", err)
+	}
+
+	wantDestroyed := []string{"api", "repo", "db"}
+	if len(destroyed) != len(wantDestroyed) {
+		t.Fatalf("Expected %d services destroyed, got %d: %v", len(wantDestroyed), len(destroyed), destroyed)
+	}
+	for i, name := range wantDestroyed {
+		if destroyed[i] != name {
+			t.Errorf("Expected teardown order %v, got %v", wantDestroyed, destroyed)
+			break
+		}
+	}
+}
+
+// TestStartUnknownDependency tests that Start reports ErrUnknownDependency
+// when a service's dependsOn names a service that was never registered.
+func TestStartUnknownDependency(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	c.RegisterWithDeps("repo", func(deps map[string]interface{}) interface{} {
+		return "repo-instance"
+	}, container.LifetimeSingleton, "db")
+
+	err := c.Start(context.Background())
+	if !errors.Is(err, container.ErrUnknownDependency) {
+		t.Fatalf("Expected ErrUnknownDependency, got %v", err)
+	}
+}
+
+// TestStartCyclicDependency tests that Start reports ErrCyclicDependency and
+// names the offending cycle when the dependency graph contains one.
+func TestStartCyclicDependency(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	c.RegisterWithDeps("a", func(deps map[string]interface{}) interface{} {
+		return "a-instance"
+	}, container.LifetimeSingleton, "b")
+
+	c.RegisterWithDeps("b", func(deps map[string]interface{}) interface{} {
+		return "b-instance"
+	}, container.LifetimeSingleton, "a")
+
+	err := c.Start(context.Background())
+	if !errors.Is(err, container.ErrCyclicDependency) {
+		t.Fatalf("Expected ErrCyclicDependency, got %v", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "a") || !strings.Contains(msg, "b") {
+		t.Errorf("Expected cycle error to name both services, got: %s", msg)
+	}
+}
+
+// TestStartStopEmitEvents tests that RegisterWithDeps, Start, and Stop emit
+// the same lifecycle events as the name- and type-based registration paths.
+func TestStartStopEmitEvents(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	events, unsubscribe := c.Subscribe(8)
+	defer unsubscribe()
+
+	c.RegisterWithDeps("db", func(deps map[string]interface{}) interface{} {
+		return "db-instance"
+	}, container.LifetimeSingleton)
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Expected Start to succeed, got error: %v", err)
+	}
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("Expected Stop to succeed, got error: %v", err)
+	}
+
+	var kinds []container.EventKind
+	for i := 0; i < 3; i++ {
+		select {
+		case evt := <-events:
+			kinds = append(kinds, evt.Kind)
+		default:
+		}
+	}
+
+	want := []container.EventKind{container.EventRegistered, container.EventResolved, container.EventDestroyed}
+	if len(kinds) != len(want) {
+		t.Fatalf("Expected %d events, got %d: %v", len(want), len(kinds), kinds)
+	}
+	for i, k := range want {
+		if kinds[i] != k {
+			t.Errorf("Expected event %d to be %v, got %v", i, k, kinds[i])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Scope carries request-lifetime instances for services registered with
+// LifetimeScoped. Singleton resolutions still defer to the parent
+// Container's shared cache, and transient resolutions always construct a new
+// instance; only scoped services are cached per-Scope.
+type Scope struct {
+	parent    *Container
+	instances map[string]interface{}
+	mu        sync.Mutex
+}
+
+// NewScope creates a Scope bound to c, ready to resolve scoped services.
+func (c *Container) NewScope() *Scope {
+	return &Scope{
+		parent:    c,
+		instances: make(map[string]interface{}),
+	}
+}
+
+// Resolve looks up name's registration on the parent Container and applies
+// its declared lifetime: singletons resolve through the parent's shared
+// cache, transients always construct anew, and scoped services are
+// constructed and cached the first time they're resolved within s.
+func (s *Scope) Resolve(name string) (interface{}, error) {
+	s.parent.mu.RLock()
+	entry, exists := s.parent.services[name]
+	s.parent.mu.RUnlock()
+
+	if !exists {
+		return nil, errors.New("service not found: " + name)
+	}
+
+	switch entry.lifetime {
+	case LifetimeSingleton:
+		return s.parent.Resolve(name)
+	case LifetimeTransient:
+		instance := entry.constructor()
+		s.parent.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+		return instance, nil
+	case LifetimeScoped:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if instance, found := s.instances[name]; found {
+			s.parent.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+			return instance, nil
+		}
+		instance := entry.constructor()
+		s.instances[name] = instance
+		s.parent.emit(Event{Kind: EventResolved, Name: name, Lifetime: entry.lifetime, At: time.Now()})
+		return instance, nil
+	default:
+		return nil, fmt.Errorf("unknown lifetime for service %s", name)
+	}
+}
+
+// Close tears down every instance constructed within s, invoking the
+// service's postDestroy hook (if any) registered on the parent Container.
+// Singleton and transient instances resolved through s are untouched, since
+// their lifetime is owned elsewhere.
+func (s *Scope) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name := range s.instances {
+		s.parent.mu.RLock()
+		fn, exists := s.parent.postDestroy[name]
+		s.parent.mu.RUnlock()
+		if exists {
+			fn()
+		}
+		delete(s.instances, name)
+		s.parent.emit(Event{Kind: EventDestroyed, Name: name, At: time.Now()})
+	}
+}
+
+// scopeContextKey is the unexported key used to flow a Scope through a
+// context.Context.
+type scopeContextKey struct{}
+
+// ContextWithScope returns a copy of ctx carrying scope, retrievable via
+// ScopeFromContext.
+func ContextWithScope(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// ScopeFromContext returns the Scope previously attached to ctx via
+// ContextWithScope, if any.
+func ScopeFromContext(ctx context.Context) (*Scope, bool) {
+	scope, ok := ctx.Value(scopeContextKey{}).(*Scope)
+	return scope, ok
+}
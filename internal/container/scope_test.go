@@ -0,0 +1,189 @@
+package container_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goletan/di/internal/container"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestLogger(t *testing.T) *zap.Logger {
+	t.Helper()
+	core, _ := observer.New(zap.InfoLevel)
+	return zap.New(core)
+}
+
+// TestScopedIdentity tests that a scoped service resolves to the same
+// instance within a scope but to a different instance across scopes.
+func TestScopedIdentity(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	count := 0
+	c.Register("scoped", func() interface{} {
+		count++
+		return count
+	}, container.LifetimeScoped)
+
+	scopeA := c.NewScope()
+	a1, err := scopeA.Resolve("scoped")
+	if err != nil {
+		t.Fatalf("Expected scoped service to resolve, got error: %v", err)
+	}
+	a2, err := scopeA.Resolve("scoped")
+	if err != nil {
+		t.Fatalf("Expected scoped service to resolve, got error: %v", err)
+	}
+	if a1 != a2 {
+		t.Errorf("Expected the same instance within a scope, got different instances")
+	}
+
+	scopeB := c.NewScope()
+	b1, err := scopeB.Resolve("scoped")
+	if err != nil {
+		t.Fatalf("Expected scoped service to resolve, got error: %v", err)
+	}
+	if a1 == b1 {
+		t.Errorf("Expected different instances across scopes, got the same instance")
+	}
+}
+
+// TestScopedSingletonDefersToParent tests that a singleton resolved through
+// a Scope shares identity with the parent Container across scopes.
+func TestScopedSingletonDefersToParent(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	c.Register("singleton", func() interface{} {
+		return &struct{}{}
+	}, container.LifetimeSingleton)
+
+	scopeA := c.NewScope()
+	scopeB := c.NewScope()
+
+	a, err := scopeA.Resolve("singleton")
+	if err != nil {
+		t.Fatalf("Expected singleton to resolve, got error: %v", err)
+	}
+	b, err := scopeB.Resolve("singleton")
+	if err != nil {
+		t.Fatalf("Expected singleton to resolve, got error: %v", err)
+	}
+	parent, err := c.Resolve("singleton")
+	if err != nil {
+		t.Fatalf("Expected singleton to resolve on parent, got error: %v", err)
+	}
+
+	if a != b || a != parent {
+		t.Errorf("Expected singleton identity to be shared across scopes and the parent container")
+	}
+}
+
+// TestScopedTransientAlwaysNew tests that a transient service always
+// constructs a new instance, even when resolved repeatedly through the same
+// Scope.
+func TestScopedTransientAlwaysNew(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	count := 0
+	c.Register("transient", func() interface{} {
+		count++
+		return &struct{ n int }{n: count}
+	}, container.LifetimeTransient)
+
+	scope := c.NewScope()
+
+	t1, err := scope.Resolve("transient")
+	if err != nil {
+		t.Fatalf("Expected transient service to resolve, got error: %v", err)
+	}
+	t2, err := scope.Resolve("transient")
+	if err != nil {
+		t.Fatalf("Expected transient service to resolve, got error: %v", err)
+	}
+	if t1 == t2 {
+		t.Errorf("Expected different instances for transient service, got the same instance")
+	}
+}
+
+// TestScopeCloseInvokesPostDestroy tests that Close runs the postDestroy
+// hook for instances constructed within the scope.
+func TestScopeCloseInvokesPostDestroy(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	c.Register("scoped", func() interface{} {
+		return &struct{}{}
+	}, container.LifetimeScoped)
+
+	destroyed := false
+	c.RegisterPostDestroy("scoped", func() {
+		destroyed = true
+	})
+
+	scope := c.NewScope()
+	if _, err := scope.Resolve("scoped"); err != nil {
+		t.Fatalf("Expected scoped service to resolve, got error: %v", err)
+	}
+
+	scope.Close()
+
+	if !destroyed {
+		t.Errorf("Expected postDestroy hook to be called on Close, but it wasn't")
+	}
+}
+
+// TestScopeEmitsEvents tests that resolving and closing a scoped service
+// through a Scope emits the same lifecycle events as the name- and
+// type-based registration paths.
+func TestScopeEmitsEvents(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	c.Register("scoped", func() interface{} {
+		return &struct{}{}
+	}, container.LifetimeScoped)
+
+	events, unsubscribe := c.Subscribe(8)
+	defer unsubscribe()
+
+	scope := c.NewScope()
+	if _, err := scope.Resolve("scoped"); err != nil {
+		t.Fatalf("Expected scoped service to resolve, got error: %v", err)
+	}
+	scope.Close()
+
+	var kinds []container.EventKind
+	for i := 0; i < 2; i++ {
+		select {
+		case evt := <-events:
+			kinds = append(kinds, evt.Kind)
+		default:
+		}
+	}
+
+	want := []container.EventKind{container.EventResolved, container.EventDestroyed}
+	if len(kinds) != len(want) {
+		t.Fatalf("Expected %d events, got %d: %v", len(want), len(kinds), kinds)
+	}
+	for i, k := range want {
+		if kinds[i] != k {
+			t.Errorf("Expected event %d to be %v, got %v", i, k, kinds[i])
+		}
+	}
+}
+
+// TestResolveScopedOutsideScopeReturnsError tests that resolving a
+// LifetimeScoped service directly on the Container, rather than through a
+// Scope, returns ErrScopeRequired instead of silently constructing a
+// transient-like instance.
+func TestResolveScopedOutsideScopeReturnsError(t *testing.T) {
+	c := container.NewContainer(newTestLogger(t))
+
+	c.Register("scoped", func() interface{} {
+		return &struct{}{}
+	}, container.LifetimeScoped)
+
+	_, err := c.Resolve("scoped")
+	if !errors.Is(err, container.ErrScopeRequired) {
+		t.Fatalf("Expected ErrScopeRequired, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrServiceNotFound is returned by Service when no registered type is
+// assignable to the requested target.
+var ErrServiceNotFound = errors.New("di: no compatible service registered")
+
+// DuplicateServiceError is returned by RegisterByType when a constructor for
+// the same concrete type has already been registered.
+type DuplicateServiceError struct {
+	Type reflect.Type
+}
+
+func (e *DuplicateServiceError) Error() string {
+	return fmt.Sprintf("di: service of type %s already registered", e.Type)
+}
+
+// typedServiceEntry holds a reflect-inspected constructor registered via
+// RegisterByType, along with its cached instance for singleton lifetimes.
+type typedServiceEntry struct {
+	constructor reflect.Value
+	lifetime    LifetimeType
+	instance    interface{}
+}
+
+// RegisterByType registers a constructor indexed by the concrete type it
+// returns, so consumers can later request it by type via Service instead of
+// by name. constructor must be a function returning either T or (T, error);
+// registering a second constructor for the same T returns a
+// *DuplicateServiceError.
+func (c *Container) RegisterByType(constructor interface{}, lifetime LifetimeType) error {
+	ctorVal := reflect.ValueOf(constructor)
+	ctorType := ctorVal.Type()
+	if ctorType.Kind() != reflect.Func {
+		return fmt.Errorf("di: constructor must be a function, got %s", ctorType.Kind())
+	}
+	if ctorType.NumOut() == 0 || ctorType.NumOut() > 2 {
+		return fmt.Errorf("di: constructor must return (T) or (T, error)")
+	}
+	if ctorType.NumOut() == 2 {
+		errType := reflect.TypeOf((*error)(nil)).Elem()
+		if !ctorType.Out(1).Implements(errType) {
+			return fmt.Errorf("di: constructor's second return value must be error")
+		}
+	}
+	outType := ctorType.Out(0)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.servicesByType[outType]; exists {
+		return &DuplicateServiceError{Type: outType}
+	}
+	c.servicesByType[outType] = &typedServiceEntry{
+		constructor: ctorVal,
+		lifetime:    lifetime,
+	}
+	c.logger.Info("Service registered by type", zap.String("type", outType.String()))
+	c.emit(Event{Kind: EventRegistered, Name: outType.String(), Lifetime: lifetime, At: time.Now()})
+	return nil
+}
+
+// Service resolves a registered constructor into target, which must be a
+// non-nil pointer to an interface or concrete type. The container looks for
+// a registration whose concrete type is assignable to *target's element
+// type, constructing (and, for singletons, caching) the instance as needed.
+// It returns ErrServiceNotFound when no compatible registration exists.
+func (c *Container) Service(target interface{}) error {
+	targetVal := reflect.ValueOf(target)
+	if targetVal.Kind() != reflect.Ptr || targetVal.IsNil() {
+		return fmt.Errorf("di: target must be a non-nil pointer, got %T", target)
+	}
+	elem := targetVal.Elem()
+	elemType := elem.Type()
+
+	c.mu.RLock()
+	var svcType reflect.Type
+	var entry *typedServiceEntry
+	for st, e := range c.servicesByType {
+		if st.AssignableTo(elemType) {
+			svcType, entry = st, e
+			break
+		}
+	}
+	c.mu.RUnlock()
+
+	if entry == nil {
+		return ErrServiceNotFound
+	}
+
+	instance, err := c.resolveTyped(svcType, entry)
+	if err != nil {
+		return err
+	}
+	elem.Set(reflect.ValueOf(instance))
+	return nil
+}
+
+// resolveTyped invokes (or reuses, for singletons) the constructor behind a
+// typedServiceEntry. It locks c.mu only around the map/cache reads and the
+// singleton write, so the constructor itself runs with the lock released.
+func (c *Container) resolveTyped(t reflect.Type, entry *typedServiceEntry) (interface{}, error) {
+	if entry.lifetime == LifetimeSingleton {
+		c.mu.RLock()
+		instance := entry.instance
+		c.mu.RUnlock()
+		if instance != nil {
+			return instance, nil
+		}
+	}
+
+	results := entry.constructor.Call(nil)
+	if len(results) == 2 && !results[1].IsNil() {
+		err := results[1].Interface().(error)
+		c.emit(Event{Kind: EventConstructFailed, Name: t.String(), Lifetime: entry.lifetime, Err: err, At: time.Now()})
+		return nil, err
+	}
+
+	instance := results[0].Interface()
+	if entry.lifetime == LifetimeSingleton {
+		c.mu.Lock()
+		entry.instance = instance
+		c.mu.Unlock()
+	}
+	c.logger.Info("Service resolved by type", zap.String("type", t.String()))
+	c.emit(Event{Kind: EventResolved, Name: t.String(), Lifetime: entry.lifetime, At: time.Now()})
+	return instance, nil
+}